@@ -0,0 +1,67 @@
+package sexp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommentsDiscardedByDefault(t *testing.T) {
+	elems, err := ParseAll("t", strings.NewReader("1 ; comment\n2 #| block |# 3 #;4 5"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(elems) != 4 {
+		t.Fatalf("want 4 elements, got %d: %v", len(elems), elems)
+	}
+	for _, e := range elems {
+		if _, ok := e.(Comment); ok {
+			t.Fatalf("did not expect Comment in result: %v", elems)
+		}
+	}
+}
+
+func TestDatumCommentKeptAsComment(t *testing.T) {
+	p := NewParser("t", strings.NewReader("#;(foo) bar"))
+	p.KeepComments = true
+
+	el, err := p.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, ok := el.(Comment)
+	if !ok {
+		t.Fatalf("want Comment, got %T", el)
+	}
+	if c.Text != "#;(foo)" {
+		t.Fatalf("want %q, got %q", "#;(foo)", c.Text)
+	}
+
+	el2, err := p.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sym, ok := el2.(Symbol); !ok || sym.Name != "bar" {
+		t.Fatalf("want Symbol bar, got %#v", el2)
+	}
+}
+
+func TestLineAndBlockCommentsKept(t *testing.T) {
+	p := NewParser("t", strings.NewReader("; hi\n#| nested #| |# block |#"))
+	p.KeepComments = true
+
+	el, err := p.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, ok := el.(Comment); !ok || c.Text != "; hi" {
+		t.Fatalf("want line comment \"; hi\", got %#v", el)
+	}
+
+	el2, err := p.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := el2.(Comment); !ok {
+		t.Fatalf("want block Comment, got %#v", el2)
+	}
+}
@@ -0,0 +1,242 @@
+package sexp
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+)
+
+// Unmarshaler 是可以从S表达式元素解码自身的接口，
+// 类似于 encoding/json 中的 Unmarshaler
+type Unmarshaler interface {
+	UnmarshalSexp(Element) error
+}
+
+// Unmarshal 解析 data 中的第一个顶层元素，并将其赋值给 v 指向的值。
+// v 必须是一个非空指针
+func Unmarshal(data []byte, v any) error {
+	p := NewParser("", bytes.NewReader(data))
+	el, err := p.Next()
+	if err != nil {
+		return err
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("sexp: Unmarshal(non-nil pointer expected, got %T)", v)
+	}
+	return decodeValue(el, rv.Elem())
+}
+
+// decodeValue 将元素 el 解码并赋值给可设置的反射值 v
+func decodeValue(el Element, v reflect.Value) error {
+	if v.CanAddr() {
+		if u, ok := v.Addr().Interface().(Unmarshaler); ok {
+			return u.UnmarshalSexp(el)
+		}
+	}
+
+	if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && isNilSymbol(el) {
+		// 与 Marshal 中 nil 指针/接口编码为 Symbol{"nil"} 对称，解码时还原为nil
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	if v.Kind() == reflect.Interface && v.NumMethod() == 0 {
+		v.Set(reflect.ValueOf(elementToAny(el)))
+		return nil
+	}
+
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeValue(el, v.Elem())
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		b, ok := el.(Bool)
+		if !ok {
+			return typeError(el, v)
+		}
+		v.SetBool(b.Value)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := el.(Integer)
+		if !ok {
+			return typeError(el, v)
+		}
+		if v.OverflowInt(i.Value) {
+			return &ParseError{Pos: el.Position(), Err: fmt.Errorf("integer %d overflows %s", i.Value, v.Type())}
+		}
+		v.SetInt(i.Value)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i, ok := el.(Integer)
+		if !ok {
+			return typeError(el, v)
+		}
+		if i.Value < 0 || v.OverflowUint(uint64(i.Value)) {
+			return &ParseError{Pos: el.Position(), Err: fmt.Errorf("integer %d overflows %s", i.Value, v.Type())}
+		}
+		v.SetUint(uint64(i.Value))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		switch n := el.(type) {
+		case Float:
+			v.SetFloat(n.Value)
+		case Integer:
+			v.SetFloat(float64(n.Value))
+		default:
+			return typeError(el, v)
+		}
+		return nil
+	case reflect.String:
+		s, ok := el.(String)
+		if !ok {
+			return typeError(el, v)
+		}
+		v.SetString(s.Value)
+		return nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if s, ok := el.(String); ok {
+				v.SetBytes([]byte(s.Value))
+				return nil
+			}
+		}
+		l, ok := el.(List)
+		if !ok {
+			return typeError(el, v)
+		}
+		slice := reflect.MakeSlice(v.Type(), len(l.Elems), len(l.Elems))
+		for i, e := range l.Elems {
+			if err := decodeValue(e, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	case reflect.Array:
+		l, ok := el.(List)
+		if !ok {
+			return typeError(el, v)
+		}
+		if len(l.Elems) != v.Len() {
+			return &ParseError{Pos: el.Position(), Err: fmt.Errorf("array length mismatch: want %d, got %d", v.Len(), len(l.Elems))}
+		}
+		for i, e := range l.Elems {
+			if err := decodeValue(e, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		l, ok := el.(List)
+		if !ok {
+			return typeError(el, v)
+		}
+		m := reflect.MakeMapWithSize(v.Type(), len(l.Elems))
+		for _, e := range l.Elems {
+			pair, ok := e.(List)
+			if !ok || len(pair.Elems) != 2 {
+				return &ParseError{Pos: e.Position(), Err: fmt.Errorf("expected (key value) pair")}
+			}
+			key := reflect.New(v.Type().Key()).Elem()
+			if err := decodeValue(pair.Elems[0], key); err != nil {
+				return err
+			}
+			val := reflect.New(v.Type().Elem()).Elem()
+			if err := decodeValue(pair.Elems[1], val); err != nil {
+				return err
+			}
+			m.SetMapIndex(key, val)
+		}
+		v.Set(m)
+		return nil
+	case reflect.Struct:
+		return decodeStruct(el, v)
+	default:
+		return fmt.Errorf("sexp: cannot unmarshal into %s", v.Type())
+	}
+}
+
+// decodeStruct 将 (key value) 列表按字段名/sexp标签赋值给结构体字段
+func decodeStruct(el Element, v reflect.Value) error {
+	l, ok := el.(List)
+	if !ok {
+		return typeError(el, v)
+	}
+
+	t := v.Type()
+	fieldByName := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name, _, skip := parseSexpTag(field)
+		if skip {
+			continue
+		}
+		fieldByName[name] = i
+	}
+
+	for _, e := range l.Elems {
+		pair, ok := e.(List)
+		if !ok || len(pair.Elems) != 2 {
+			return &ParseError{Pos: e.Position(), Err: fmt.Errorf("expected (key value) pair")}
+		}
+		key, ok := pair.Elems[0].(Symbol)
+		if !ok {
+			return &ParseError{Pos: pair.Elems[0].Position(), Err: fmt.Errorf("expected symbol key")}
+		}
+		idx, ok := fieldByName[key.Name]
+		if !ok {
+			// 未知字段，忽略
+			continue
+		}
+		if err := decodeValue(pair.Elems[1], v.Field(idx)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// typeError 返回一个携带源位置信息的"类型不匹配"错误
+func typeError(el Element, v reflect.Value) error {
+	return &ParseError{Pos: el.Position(), Err: fmt.Errorf("cannot assign %T to %s", el, v.Type())}
+}
+
+// isNilSymbol 判断el是否是Marshal为nil指针/接口所产生的 Symbol{"nil"}
+func isNilSymbol(el Element) bool {
+	s, ok := el.(Symbol)
+	return ok && s.Name == "nil"
+}
+
+// elementToAny 在没有具体目标类型时（即目标是 any/interface{}），
+// 把元素转换为惯用的Go值：Integer->int64, Float->float64, String->string,
+// Symbol->string, List->[]any
+func elementToAny(el Element) any {
+	switch e := el.(type) {
+	case Integer:
+		return e.Value
+	case Float:
+		return e.Value
+	case String:
+		return e.Value
+	case Bool:
+		return e.Value
+	case Symbol:
+		return e.Name
+	case List:
+		out := make([]any, len(e.Elems))
+		for i, c := range e.Elems {
+			out[i] = elementToAny(c)
+		}
+		return out
+	default:
+		return el
+	}
+}
@@ -0,0 +1,85 @@
+package sexp
+
+import "testing"
+
+type decPerson struct {
+	Name string
+	Age  int
+}
+
+type decWithPtr struct {
+	Name  string
+	Extra *decPerson
+}
+
+func TestUnmarshalPrimitives(t *testing.T) {
+	var i int
+	if err := Unmarshal([]byte("42"), &i); err != nil || i != 42 {
+		t.Fatalf("int: got %d, %v", i, err)
+	}
+
+	var b bool
+	if err := Unmarshal([]byte("#t"), &b); err != nil || !b {
+		t.Fatalf("bool: got %v, %v", b, err)
+	}
+
+	var s string
+	if err := Unmarshal([]byte(`"hi"`), &s); err != nil || s != "hi" {
+		t.Fatalf("string: got %q, %v", s, err)
+	}
+
+	var nums []int
+	if err := Unmarshal([]byte("(1 2 3)"), &nums); err != nil {
+		t.Fatal(err)
+	}
+	if len(nums) != 3 || nums[0] != 1 || nums[2] != 3 {
+		t.Fatalf("slice: got %v", nums)
+	}
+}
+
+func TestUnmarshalStruct(t *testing.T) {
+	var p decPerson
+	if err := Unmarshal([]byte(`((Name "Ann") (Age 30))`), &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "Ann" || p.Age != 30 {
+		t.Fatalf("got %+v", p)
+	}
+}
+
+func TestUnmarshalIntOverflowErrors(t *testing.T) {
+	var i8 int8
+	if err := Unmarshal([]byte("200"), &i8); err == nil {
+		t.Fatal("expected overflow error")
+	}
+}
+
+func TestMarshalUnmarshalNilPointerRoundtrip(t *testing.T) {
+	in := decWithPtr{Name: "a", Extra: nil}
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out decWithPtr
+	out.Extra = &decPerson{Name: "should be cleared"}
+	if err := Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Extra != nil {
+		t.Fatalf("want nil Extra after round-trip, got %+v", out.Extra)
+	}
+
+	in2 := decWithPtr{Name: "b", Extra: &decPerson{Name: "Bo", Age: 5}}
+	data2, err := Marshal(in2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out2 decWithPtr
+	if err := Unmarshal(data2, &out2); err != nil {
+		t.Fatal(err)
+	}
+	if out2.Extra == nil || out2.Extra.Name != "Bo" || out2.Extra.Age != 5 {
+		t.Fatalf("want non-nil Extra round-tripped, got %+v", out2.Extra)
+	}
+}
@@ -0,0 +1,222 @@
+package sexp
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Marshaler 是可以将自身编码为S表达式元素的接口，
+// 类似于 encoding/json 中的 Marshaler
+type Marshaler interface {
+	MarshalSexp() (Element, error)
+}
+
+// Marshal 将 v 编码为S表达式的字节表示
+func Marshal(v any) ([]byte, error) {
+	el, err := marshalValue(reflect.ValueOf(v))
+	if err != nil {
+		return nil, err
+	}
+	return []byte(el.String()), nil
+}
+
+// Encoder 将Go值写入底层的 io.Writer，编码为S表达式
+type Encoder struct {
+	w      io.Writer
+	prefix string
+	indent string
+}
+
+// NewEncoder 创建一个将编码结果写入 w 的 Encoder
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Indent 设置编码时使用的前缀和缩进，用于美化输出；
+// 调用前默认不换行，输出紧凑的单行表示
+func (e *Encoder) Indent(prefix, indent string) {
+	e.prefix = prefix
+	e.indent = indent
+}
+
+// Encode 将 v 编码为S表达式并写入底层的 io.Writer
+func (e *Encoder) Encode(v any) error {
+	el, err := marshalValue(reflect.ValueOf(v))
+	if err != nil {
+		return err
+	}
+
+	var s string
+	if e.indent == "" {
+		s = el.String()
+	} else {
+		var b strings.Builder
+		writePretty(&b, el, 0, e.prefix, e.indent)
+		s = b.String()
+	}
+
+	_, err = io.WriteString(e.w, s+"\n")
+	return err
+}
+
+// prettyWidth 是超过此宽度的列表会被拆分为多行
+const prettyWidth = 80
+
+// writePretty 将 el 以美化格式写入 b，长列表会按 indent 拆分为多行
+func writePretty(b *strings.Builder, el Element, depth int, prefix, indent string) {
+	l, ok := el.(List)
+	if !ok || len(l.Elems) == 0 {
+		b.WriteString(el.String())
+		return
+	}
+
+	oneLine := l.String()
+	if len(oneLine) <= prettyWidth {
+		b.WriteString(oneLine)
+		return
+	}
+
+	b.WriteString("(")
+	childPrefix := prefix + strings.Repeat(indent, depth+1)
+	for i, child := range l.Elems {
+		if i > 0 {
+			b.WriteString("\n")
+			b.WriteString(childPrefix)
+		}
+		writePretty(b, child, depth+1, prefix, indent)
+	}
+	if l.Tail != nil {
+		b.WriteString(" . ")
+		b.WriteString(l.Tail.String())
+	}
+	b.WriteString(")")
+}
+
+// marshalValue 使用反射将任意Go值转换为S表达式元素
+func marshalValue(v reflect.Value) (Element, error) {
+	if !v.IsValid() {
+		return Symbol{Name: "nil"}, nil
+	}
+
+	if m, ok := v.Interface().(Marshaler); ok {
+		return m.MarshalSexp()
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return Symbol{Name: "nil"}, nil
+		}
+		return marshalValue(v.Elem())
+	case reflect.Bool:
+		return Bool{Value: v.Bool()}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Integer{Value: v.Int()}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Integer{Value: int64(v.Uint())}, nil
+	case reflect.Float32, reflect.Float64:
+		return Float{Value: v.Float()}, nil
+	case reflect.String:
+		return String{Value: v.String()}, nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return String{Value: string(v.Bytes())}, nil
+		}
+		elems := make([]Element, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			e, err := marshalValue(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			elems[i] = e
+		}
+		return List{Elems: elems}, nil
+	case reflect.Map:
+		return marshalMap(v)
+	case reflect.Struct:
+		return marshalStruct(v)
+	default:
+		return nil, fmt.Errorf("sexp: cannot marshal %s", v.Type())
+	}
+}
+
+// marshalMap 将map编码为关联列表：((key1 value1) (key2 value2) ...)
+func marshalMap(v reflect.Value) (Element, error) {
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+
+	elems := make([]Element, len(keys))
+	for i, k := range keys {
+		key, err := marshalValue(k)
+		if err != nil {
+			return nil, err
+		}
+		val, err := marshalValue(v.MapIndex(k))
+		if err != nil {
+			return nil, err
+		}
+		elems[i] = List{Elems: []Element{key, val}}
+	}
+	return List{Elems: elems}, nil
+}
+
+// marshalStruct 将结构体编码为 ((field1 value1) (field2 value2) ...)，
+// 字段名由 sexp 标签（形如 "name,omitempty"）或字段名本身决定
+func marshalStruct(v reflect.Value) (Element, error) {
+	t := v.Type()
+	var elems []Element
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			// 未导出字段
+			continue
+		}
+
+		name, omitempty, skip := parseSexpTag(field)
+		if skip {
+			continue
+		}
+
+		fv := v.Field(i)
+		if omitempty && fv.IsZero() {
+			continue
+		}
+
+		val, err := marshalValue(fv)
+		if err != nil {
+			return nil, err
+		}
+
+		elems = append(elems, List{Elems: []Element{Symbol{Name: name}, val}})
+	}
+	return List{Elems: elems}, nil
+}
+
+// parseSexpTag 解析字段的 `sexp:"name,omitempty"` 标签，
+// name为空时回退为字段名，标签为"-"时表示跳过该字段
+func parseSexpTag(field reflect.StructField) (name string, omitempty bool, skip bool) {
+	name = field.Name
+	tag := field.Tag.Get("sexp")
+	if tag == "" {
+		return name, false, false
+	}
+	if tag == "-" {
+		return "", false, true
+	}
+
+	parts := strings.Split(tag, ",")
+	if parts[0] != "" {
+		name = parts[0]
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+	return name, omitempty, false
+}
@@ -0,0 +1,73 @@
+package sexp
+
+import (
+	"bytes"
+	"testing"
+)
+
+type encPerson struct {
+	Name   string
+	Age    int
+	Hidden string `sexp:"-"`
+	Note   string `sexp:"note,omitempty"`
+}
+
+func TestMarshalPrimitives(t *testing.T) {
+	cases := []struct {
+		in   any
+		want string
+	}{
+		{42, "42"},
+		{true, "#t"},
+		{false, "#f"},
+		{"hi", `"hi"`},
+		{[]int{1, 2, 3}, "(1 2 3)"},
+	}
+	for _, c := range cases {
+		got, err := Marshal(c.in)
+		if err != nil {
+			t.Fatalf("Marshal(%v): %v", c.in, err)
+		}
+		if string(got) != c.want {
+			t.Errorf("Marshal(%v) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestMarshalStructUsesTags(t *testing.T) {
+	got, err := Marshal(encPerson{Name: "Ann", Age: 30, Hidden: "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `((Name "Ann") (Age 30))`
+	if string(got) != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestMarshalNilPointer(t *testing.T) {
+	var p *int
+	got, err := Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "nil" {
+		t.Fatalf("want \"nil\", got %q", got)
+	}
+}
+
+func TestEncoderIndentWrapsLongLists(t *testing.T) {
+	var b bytes.Buffer
+	e := NewEncoder(&b)
+	e.Indent("", "  ")
+	long := make([]string, 0, 10)
+	for i := 0; i < 10; i++ {
+		long = append(long, "element-value-long-enough-to-force-wrap")
+	}
+	if err := e.Encode(long); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(b.Bytes(), []byte("\n  ")) {
+		t.Fatalf("expected indented multi-line output, got %q", b.String())
+	}
+}
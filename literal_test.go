@@ -0,0 +1,96 @@
+package sexp
+
+import "testing"
+
+func TestBoolLiterals(t *testing.T) {
+	cases := []struct {
+		src  string
+		want bool
+	}{
+		{"#t", true}, {"#true", true}, {"#f", false}, {"#false", false},
+	}
+	for _, c := range cases {
+		b, ok := parseOne(t, c.src).(Bool)
+		if !ok {
+			t.Fatalf("%q: want Bool, got not Bool", c.src)
+		}
+		if b.Value != c.want {
+			t.Errorf("%q: got %v, want %v", c.src, b.Value, c.want)
+		}
+	}
+}
+
+func TestCharLiterals(t *testing.T) {
+	cases := []struct {
+		src  string
+		want rune
+	}{
+		{`#\a`, 'a'},
+		{`#\space`, ' '},
+		{`#\newline`, '\n'},
+		{`#\tab`, '\t'},
+		{`#\λ`, 'λ'},
+	}
+	for _, c := range cases {
+		ch, ok := parseOne(t, c.src).(Char)
+		if !ok {
+			t.Fatalf("%q: want Char, got not Char", c.src)
+		}
+		if ch.Value != c.want {
+			t.Errorf("%q: got %q, want %q", c.src, ch.Value, c.want)
+		}
+	}
+}
+
+func TestCharStringRoundtrip(t *testing.T) {
+	cases := []string{`#\a`, `#\space`, `#\newline`}
+	for _, src := range cases {
+		ch := parseOne(t, src).(Char)
+		if got := ch.String(); got != src {
+			t.Errorf("String() = %q, want %q", got, src)
+		}
+	}
+}
+
+func TestVectorLiteral(t *testing.T) {
+	el := parseOne(t, "#(1 2 3)")
+	v, ok := el.(Vector)
+	if !ok {
+		t.Fatalf("want Vector, got %T", el)
+	}
+	if len(v.Elems) != 3 {
+		t.Fatalf("want 3 elems, got %d", len(v.Elems))
+	}
+	if got, want := v.String(), "#(1 2 3)"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+// TestBoolCodecAgreesWithLiteralSyntax 回归测试：Marshal/Unmarshal必须使用
+// Bool元素，而不是Symbol "true"/"false"，否则编解码结果与解析器自身的
+// 字面量语法不一致（chunk0-7）
+func TestBoolCodecAgreesWithLiteralSyntax(t *testing.T) {
+	data, err := Marshal(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "#t" {
+		t.Fatalf("Marshal(true) = %q, want %q", data, "#t")
+	}
+
+	var b bool
+	if err := Unmarshal([]byte("#f"), &b); err != nil {
+		t.Fatal(err)
+	}
+	if b != false {
+		t.Fatalf("Unmarshal(#f) = %v, want false", b)
+	}
+
+	var any any
+	if err := Unmarshal([]byte("#t"), &any); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := any.(bool); !ok || !v {
+		t.Fatalf("Unmarshal into interface{} = %#v, want true", any)
+	}
+}
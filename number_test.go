@@ -0,0 +1,78 @@
+package sexp
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func parseOne(t *testing.T, src string) Element {
+	t.Helper()
+	el, err := NewParser("t", strings.NewReader(src)).Next()
+	if err != nil {
+		t.Fatalf("parse %q: %v", src, err)
+	}
+	return el
+}
+
+func TestRadixIntegerRoundtrip(t *testing.T) {
+	cases := []string{"0x1A", "0o17", "0b1010", "-0x1A"}
+	for _, src := range cases {
+		el := parseOne(t, src)
+		i, ok := el.(Integer)
+		if !ok {
+			t.Fatalf("%q: want Integer, got %T", src, el)
+		}
+		if got := i.String(); !strings.EqualFold(got, src) {
+			t.Errorf("%q: String() = %q", src, got)
+		}
+	}
+}
+
+func TestBigIntOnOverflow(t *testing.T) {
+	src := "99999999999999999999999999999"
+	el := parseOne(t, src)
+	bi, ok := el.(BigInt)
+	if !ok {
+		t.Fatalf("want BigInt, got %T", el)
+	}
+	want, _ := new(big.Int).SetString(src, 10)
+	if bi.Value.Cmp(want) != 0 {
+		t.Fatalf("got %s, want %s", bi.Value, want)
+	}
+}
+
+func TestRationalLiteral(t *testing.T) {
+	el := parseOne(t, "3/4")
+	r, ok := el.(Rational)
+	if !ok {
+		t.Fatalf("want Rational, got %T", el)
+	}
+	if r.String() != "3/4" {
+		t.Fatalf("got %s", r)
+	}
+}
+
+func TestScientificNotationFloat(t *testing.T) {
+	el := parseOne(t, "1.5e3")
+	f, ok := el.(Float)
+	if !ok {
+		t.Fatalf("want Float, got %T", el)
+	}
+	if f.Value != 1500 {
+		t.Fatalf("got %v", f.Value)
+	}
+}
+
+func TestInfAndNanLiterals(t *testing.T) {
+	if f := parseOne(t, "+inf.0").(Float); !math.IsInf(f.Value, 1) {
+		t.Fatalf("want +inf, got %v", f.Value)
+	}
+	if f := parseOne(t, "-inf.0").(Float); !math.IsInf(f.Value, -1) {
+		t.Fatalf("want -inf, got %v", f.Value)
+	}
+	if f := parseOne(t, "+nan.0").(Float); !math.IsNaN(f.Value) {
+		t.Fatalf("want nan, got %v", f.Value)
+	}
+}
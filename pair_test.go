@@ -0,0 +1,71 @@
+package sexp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDottedListParsingAndString(t *testing.T) {
+	el := parseOne(t, "(1 2 . 3)")
+	l, ok := el.(List)
+	if !ok {
+		t.Fatalf("want List, got %T", el)
+	}
+	if l.Tail == nil {
+		t.Fatal("want non-nil Tail for improper list")
+	}
+	if got, want := l.String(), "(1 2 . 3)"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+// TestListStringFlattensProperTail 回归测试：Tail本身是一条以空列表结尾的列表链时，
+// List.String() 应展平为正规列表记法，而不是误用点对记法（chunk0-6）
+func TestListStringFlattensProperTail(t *testing.T) {
+	inner := List{Elems: []Element{Integer{Value: 3}, Integer{Value: 4}}}
+	outer := List{Elems: []Element{Integer{Value: 1}, Integer{Value: 2}}, Tail: inner}
+
+	want := "(1 2 3 4)"
+	if got := outer.String(); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestListStringKeepsGenuineDottedTail(t *testing.T) {
+	outer := List{Elems: []Element{Integer{Value: 1}}, Tail: Symbol{Name: "x"}}
+	want := "(1 . x)"
+	if got := outer.String(); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestConsCarCdr(t *testing.T) {
+	p := Cons(Integer{Value: 1}, Integer{Value: 2})
+	car, err := Car(p)
+	if err != nil || car.(Integer).Value != 1 {
+		t.Fatalf("Car: %v, %v", car, err)
+	}
+	cdr, err := Cdr(p)
+	if err != nil || cdr.(Integer).Value != 2 {
+		t.Fatalf("Cdr: %v, %v", cdr, err)
+	}
+}
+
+func TestCarCdrOfList(t *testing.T) {
+	el, err := ParseAll("t", strings.NewReader("(1 2 3)"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := el[0]
+	car, err := Car(l)
+	if err != nil || car.(Integer).Value != 1 {
+		t.Fatalf("Car: %v, %v", car, err)
+	}
+	cdr, err := Cdr(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := cdr.String(), "(2 3)"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,63 @@
+package sexp
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestNextAndParseAll(t *testing.T) {
+	elems, err := ParseAll("t", strings.NewReader("1 2 (3 4)"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(elems) != 3 {
+		t.Fatalf("want 3 elements, got %d: %v", len(elems), elems)
+	}
+
+	p := NewParser("t", strings.NewReader(""))
+	if _, err := p.Next(); err != io.EOF {
+		t.Fatalf("want io.EOF on empty input, got %v", err)
+	}
+}
+
+func TestPositionsStartAtOne(t *testing.T) {
+	el, err := NewParser("t", strings.NewReader("a")).Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := el.Position(); p.Line != 1 || p.Col != 1 {
+		t.Fatalf("want 1:1, got %d:%d", p.Line, p.Col)
+	}
+
+	el2, err := NewParser("t", strings.NewReader("  a")).Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := el2.Position(); p.Line != 1 || p.Col != 3 {
+		t.Fatalf("want 1:3, got %d:%d", p.Line, p.Col)
+	}
+
+	el3, err := NewParser("t", strings.NewReader("(a\n  b)")).Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := el3.(List)
+	if p := l.Elems[1].Position(); p.Line != 2 || p.Col != 3 {
+		t.Fatalf("want 2:3, got %d:%d", p.Line, p.Col)
+	}
+}
+
+func TestParseErrorHasPosition(t *testing.T) {
+	_, err := NewParser("t", strings.NewReader(")")).Next()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	pe, ok := err.(*ParseError)
+	if !ok {
+		t.Fatalf("want *ParseError, got %T", err)
+	}
+	if pe.Pos.Line != 1 || pe.Pos.Col != 1 {
+		t.Fatalf("want 1:1, got %d:%d", pe.Pos.Line, pe.Pos.Col)
+	}
+}
@@ -1,29 +1,71 @@
 package sexp
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"math"
+	"math/big"
 	"strconv"
 	"strings"
 )
 
+// Pos 记录一个元素或错误在源文件中的位置
+type Pos struct {
+	// File 是文件名
+	File string
+	// Line 是行号，从1开始
+	Line int
+	// Col 是列号，从1开始
+	Col int
+}
+
+// String 返回位置的字符串表示，形如 "file:line:col"
+func (p Pos) String() string {
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Col)
+}
+
+// ParseError 是携带源位置信息的解析错误
+type ParseError struct {
+	Pos Pos
+	Err error
+}
+
+// Error 返回错误的字符串表示
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Err)
+}
+
+// Unwrap 返回底层错误，便于使用 errors.Is/errors.As
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ErrNotClosed 表示列表或字符串未正确闭合
 type ErrNotClosed struct {
-	message string
+	Pos      Pos
 	IsString bool
 }
 
 func (e ErrNotClosed) Error() string {
-	return e.message
+	if e.IsString {
+		return fmt.Sprintf(`expected '"' at %s`, e.Pos)
+	}
+	return fmt.Sprintf("expected ')' at %s", e.Pos)
 }
 
 // Element 是S表达式的元素
 type Element interface {
 	// String 返回S表达式的字符串表示
 	String() string
+	// Position 返回该元素在源文件中的位置
+	Position() Pos
 }
 
 // Symbol 是S表达式的符号
 type Symbol struct {
 	Name string
+	Pos  Pos
 }
 
 // String 返回S表达式的字符串表示
@@ -31,9 +73,15 @@ func (s Symbol) String() string {
 	return s.Name
 }
 
+// Position 返回该元素在源文件中的位置
+func (s Symbol) Position() Pos {
+	return s.Pos
+}
+
 // String 是S表达式的字符串
 type String struct {
 	Value string
+	Pos   Pos
 }
 
 // String 返回S表达式的字符串表示
@@ -41,68 +89,386 @@ func (s String) String() string {
 	return strconv.Quote(s.Value)
 }
 
+// Position 返回该元素在源文件中的位置
+func (s String) Position() Pos {
+	return s.Pos
+}
+
 // Integer 是S表达式的整数
-type Integer int64
+type Integer struct {
+	Value int64
+	Pos   Pos
+	// Radix 记录字面量使用的进制（16/8/2），0表示十进制，仅用于String()回显原始记法
+	Radix int
+}
 
-// String 返回S表达式的字符串表示
+// String 返回S表达式的字符串表示，保留原始的进制记法
 func (i Integer) String() string {
-	return fmt.Sprintf("%d", i)
+	v := i.Value
+	var prefix string
+	switch i.Radix {
+	case 16:
+		prefix = "0x"
+	case 8:
+		prefix = "0o"
+	case 2:
+		prefix = "0b"
+	default:
+		return fmt.Sprintf("%d", v)
+	}
+	if v < 0 {
+		return "-" + prefix + strconv.FormatUint(uint64(-v), i.Radix)
+	}
+	return prefix + strconv.FormatInt(v, i.Radix)
+}
+
+// Position 返回该元素在源文件中的位置
+func (i Integer) Position() Pos {
+	return i.Pos
+}
+
+// BigInt 是超出int64范围的S表达式整数
+type BigInt struct {
+	Value *big.Int
+	Pos   Pos
+}
+
+// String 返回S表达式的字符串表示
+func (b BigInt) String() string {
+	return b.Value.String()
+}
+
+// Position 返回该元素在源文件中的位置
+func (b BigInt) Position() Pos {
+	return b.Pos
+}
+
+// Rational 是S表达式的有理数，如 3/4
+type Rational struct {
+	Value *big.Rat
+	Pos   Pos
+}
+
+// String 返回S表达式的字符串表示
+func (r Rational) String() string {
+	return r.Value.RatString()
+}
+
+// Position 返回该元素在源文件中的位置
+func (r Rational) Position() Pos {
+	return r.Pos
 }
 
 // Float 是S表达式的浮点数
-type Float float64
+type Float struct {
+	Value float64
+	Pos   Pos
+}
 
 // String 返回S表达式的字符串表示
 func (f Float) String() string {
-	return fmt.Sprintf("%f", f)
+	switch {
+	case math.IsInf(f.Value, 1):
+		return "+inf.0"
+	case math.IsInf(f.Value, -1):
+		return "-inf.0"
+	case math.IsNaN(f.Value):
+		return "+nan.0"
+	default:
+		return fmt.Sprintf("%f", f.Value)
+	}
 }
 
-// List 是S表达式的列表
-type List []Element
+// Position 返回该元素在源文件中的位置
+func (f Float) Position() Pos {
+	return f.Pos
+}
 
-// String 返回S表达式的字符串表示
+// List 是S表达式的列表。Tail非nil时表示点对（非正规）列表，如 (a b . c)
+type List struct {
+	Elems []Element
+	Tail  Element
+	Pos   Pos
+}
+
+// String 返回S表达式的字符串表示，Tail非nil时使用点对记法
 func (l List) String() string {
 	var parts []string
-	for _, e := range l {
+	for _, e := range l.Elems {
 		parts = append(parts, e.String())
 	}
+
+	// 若Tail本身是一条以空列表结尾的（点对）列表链，则将其展平为正规列表，
+	// 只有当链的末端确实是非正规（Tail非nil且非空）时才使用点对记法
+	tail := l.Tail
+	for tail != nil {
+		next, ok := tail.(List)
+		if !ok {
+			break
+		}
+		parts = append(parts, wrapTailElems(next.Elems)...)
+		tail = next.Tail
+	}
+
+	if tail != nil {
+		return fmt.Sprintf("(%s . %s)", strings.Join(parts, " "), tail.String())
+	}
 	return fmt.Sprintf("(%s)", strings.Join(parts, " "))
 }
 
-// Parse 将S表达式的字符串表示转换为S表达式
-func Parse(file string,s string) (Element, error) {
-	// 创建一个新的解析器
-	p := parser{
-		// 设置文件名
+// wrapTailElems 将一组元素转换为它们的字符串表示，供 List.String() 展平Tail链时复用
+func wrapTailElems(elems []Element) []string {
+	out := make([]string, len(elems))
+	for i, e := range elems {
+		out[i] = e.String()
+	}
+	return out
+}
+
+// Position 返回该元素在源文件中的位置
+func (l List) Position() Pos {
+	return l.Pos
+}
+
+// Pair 是经典的car/cdr结构体，用于表示由 Cons 手工构造的点对结构；
+// 解析器本身产生的点对列表使用 List.Tail 表示，见 Car/Cdr 以统一处理两者
+type Pair struct {
+	Car, Cdr Element
+	Pos      Pos
+}
+
+// String 返回S表达式的字符串表示：若Cdr一路是Pair链并以空列表结尾，
+// 则按正规列表输出，否则使用点对记法
+func (p Pair) String() string {
+	var parts []string
+	var cur Element = p
+	for {
+		pair, ok := cur.(Pair)
+		if !ok {
+			break
+		}
+		parts = append(parts, pair.Car.String())
+		cur = pair.Cdr
+	}
+	if l, ok := cur.(List); ok && len(l.Elems) == 0 && l.Tail == nil {
+		return fmt.Sprintf("(%s)", strings.Join(parts, " "))
+	}
+	return fmt.Sprintf("(%s . %s)", strings.Join(parts, " "), cur.String())
+}
+
+// Position 返回该元素在源文件中的位置
+func (p Pair) Position() Pos {
+	return p.Pos
+}
+
+// Cons 构造一个car/cdr点对
+func Cons(a, b Element) Element {
+	return Pair{Car: a, Cdr: b}
+}
+
+// Car 返回e的第一个元素，e必须是非空的List或Pair
+func Car(e Element) (Element, error) {
+	switch v := e.(type) {
+	case Pair:
+		return v.Car, nil
+	case List:
+		if len(v.Elems) == 0 {
+			return nil, fmt.Errorf("sexp: Car of empty list")
+		}
+		return v.Elems[0], nil
+	default:
+		return nil, fmt.Errorf("sexp: Car of non-pair %T", e)
+	}
+}
+
+// Cdr 返回e去掉第一个元素后的剩余部分，e必须是非空的List或Pair
+func Cdr(e Element) (Element, error) {
+	switch v := e.(type) {
+	case Pair:
+		return v.Cdr, nil
+	case List:
+		if len(v.Elems) == 0 {
+			return nil, fmt.Errorf("sexp: Cdr of empty list")
+		}
+		if len(v.Elems) == 1 {
+			if v.Tail != nil {
+				return v.Tail, nil
+			}
+			return List{}, nil
+		}
+		return List{Elems: v.Elems[1:], Tail: v.Tail, Pos: v.Pos}, nil
+	default:
+		return nil, fmt.Errorf("sexp: Cdr of non-pair %T", e)
+	}
+}
+
+// Comment 是一个注释，仅当 Parser.KeepComments 为 true 时才会出现在解析结果中。
+// Text 保留注释的原始文本（含 `;`、`#|`/`|#` 或 `#;`+被丢弃的元素）
+type Comment struct {
+	Text string
+	Pos  Pos
+}
+
+// String 返回S表达式的字符串表示
+func (c Comment) String() string {
+	return c.Text
+}
+
+// Position 返回该元素在源文件中的位置
+func (c Comment) Position() Pos {
+	return c.Pos
+}
+
+// Bool 是S表达式的布尔字面量（#t/#true/#f/#false）
+type Bool struct {
+	Value bool
+	Pos   Pos
+}
+
+// String 返回S表达式的字符串表示
+func (b Bool) String() string {
+	if b.Value {
+		return "#t"
+	}
+	return "#f"
+}
+
+// Position 返回该元素在源文件中的位置
+func (b Bool) Position() Pos {
+	return b.Pos
+}
+
+// Char 是S表达式的字符字面量，如 #\a、#\space、#\λ
+type Char struct {
+	Value rune
+	Pos   Pos
+}
+
+// String 返回S表达式的字符串表示
+func (c Char) String() string {
+	switch c.Value {
+	case ' ':
+		return `#\space`
+	case '\n':
+		return `#\newline`
+	case '\t':
+		return `#\tab`
+	case '\r':
+		return `#\return`
+	case 0:
+		return `#\null`
+	case '\b':
+		return `#\backspace`
+	case 0x7f:
+		return `#\delete`
+	case 0x1b:
+		return `#\escape`
+	case '\a':
+		return `#\alarm`
+	}
+	if c.Value < 0x20 {
+		return fmt.Sprintf(`#\u%x`, c.Value)
+	}
+	return `#\` + string(c.Value)
+}
+
+// Position 返回该元素在源文件中的位置
+func (c Char) Position() Pos {
+	return c.Pos
+}
+
+// Vector 是S表达式的向量字面量 #(...)，区别于 List
+type Vector struct {
+	Elems []Element
+	Pos   Pos
+}
+
+// String 返回S表达式的字符串表示
+func (v Vector) String() string {
+	var parts []string
+	for _, e := range v.Elems {
+		parts = append(parts, e.String())
+	}
+	return fmt.Sprintf("#(%s)", strings.Join(parts, " "))
+}
+
+// Position 返回该元素在源文件中的位置
+func (v Vector) Position() Pos {
+	return v.Pos
+}
+
+// NewParser 创建一个基于 io.Reader 的流式解析器，file 仅用于错误和位置信息中的文件名
+func NewParser(file string, r io.Reader) *Parser {
+	return &Parser{
 		file: file,
-		// 将输入字符串转换为字符切片
-		input: []rune(s),
-		// 初始化位置
-		pos: 0,
+		r:    bufio.NewReader(r),
+		line: 1,
+		col:  1,
 	}
+}
 
-	// 调用解析器的parse函数进行解析
-	return p.parse()
+// ParseAll 从 r 中读取并解析所有顶层元素
+func ParseAll(file string, r io.Reader) ([]Element, error) {
+	p := NewParser(file, r)
+	var elems []Element
+	for {
+		e, err := p.Next()
+		if err == io.EOF {
+			return elems, nil
+		}
+		if err != nil {
+			return elems, err
+		}
+		elems = append(elems, e)
+	}
 }
 
-// parser 是S表达式的解析器
-type parser struct {
-	// 文件名
+// Parser 是S表达式的流式解析器，内部维护一个基于 bufio.Reader 的小型符文缓冲区，
+// 因此即使是巨大的文件或管道/套接字也无需一次性载入内存
+type Parser struct {
+	// 文件名，仅用于报错
 	file string
-	// 输入字符串
-	input []rune
-	// 当前位置
-	pos int
-	// 当前行号
-	line int
-	// 当前列号
-	col int
-}
-
-// parse 将输入的字符串解析为S表达式
-func (p *parser) parse() (Element, error) {
+	// 底层的缓冲读取器
+	r *bufio.Reader
+	// 当前行号、列号（均从1开始）
+	line, col int
+	// 预读但尚未消费的符文缓冲区
+	buf []rune
+	// 上一次 read 之前的位置，供 unread 回退一次使用
+	lastLine, lastCol int
+	hasLast           bool
+	// KeepComments 为 true 时，注释会作为 Comment 元素保留在解析结果中，
+	// 而不是像空白字符一样被直接丢弃
+	KeepComments bool
+}
+
+// Next 解析并返回下一个顶层元素，输入耗尽时返回 io.EOF
+func (p *Parser) Next() (Element, error) {
+	if err := p.skipWhitespace(); err != nil {
+		return nil, err
+	}
+	if p.peek() == 0 {
+		return nil, io.EOF
+	}
+	return p.parse()
+}
+
+// pos 返回解析器当前的位置
+func (p *Parser) pos() Pos {
+	return Pos{File: p.file, Line: p.line, Col: p.col}
+}
+
+// errorf 构造一个携带当前位置信息的 ParseError
+func (p *Parser) errorf(format string, args ...interface{}) error {
+	return &ParseError{Pos: p.pos(), Err: fmt.Errorf(format, args...)}
+}
+
+// parse 将输入解析为一个S表达式元素
+func (p *Parser) parse() (Element, error) {
 	// 跳过空白字符
-	p.skipWhitespace()
+	if err := p.skipWhitespace(); err != nil {
+		return nil, err
+	}
 
 	// 获取当前字符
 	ch := p.peek()
@@ -110,17 +476,35 @@ func (p *parser) parse() (Element, error) {
 	// 根据当前字符进行解析
 	switch {
 	case ch == 0:
-		// 如果是EOF，则返回nil
-		return nil, nil
+		// 如果是EOF，则返回错误
+		return nil, p.errorf("unexpected EOF")
 	case ch == ')':
 		// 如果是右括号，则返回错误
-		return nil, fmt.Errorf("unexpected ')' at %s:%d:%d", p.file, p.line, p.col)
+		return nil, p.errorf("unexpected ')'")
 	case ch == '(':
 		// 如果是左括号，则解析列表
 		return p.parseList()
 	case ch == '"':
 		// 如果是双引号，则解析字符串
 		return p.parseString()
+	case ch == ';':
+		// 只有 KeepComments 为 true 时才会走到这里，否则已在 skipWhitespace 中被丢弃
+		return p.parseLineComment()
+	case ch == '#' && p.peek2() == '|':
+		// 同上，块注释
+		return p.parseBlockComment()
+	case ch == '#' && p.peek2() == ';':
+		// 数据注释：丢弃紧随其后的一个元素
+		return p.parseDatumComment()
+	case ch == '#' && (p.peek2() == 't' || p.peek2() == 'f'):
+		// #t/#true/#f/#false 布尔字面量
+		return p.parseBool()
+	case ch == '#' && p.peek2() == '\\':
+		// #\a #\space #\newline #\λ 等字符字面量
+		return p.parseChar()
+	case ch == '#' && p.peek2() == '(':
+		// #(...) 向量字面量
+		return p.parseVector()
 	case ch == '+' || ch == '-' || (ch >= '0' && ch <= '9'):
 		// 如果是数字，则解析整数或浮点数
 		return p.parseNumber()
@@ -131,58 +515,205 @@ func (p *parser) parse() (Element, error) {
 }
 
 // parseList 解析列表
-func (p *parser) parseList(ops ...string) (Element, error) {
+func (p *Parser) parseList(ops ...string) (Element, error) {
+	start := p.pos()
+
 	// 读取左括号
 	p.read()
 
 	// 跳过空白字符
-	p.skipWhitespace()
+	if err := p.skipWhitespace(); err != nil {
+		return nil, err
+	}
 
 	// 创建一个空列表
-	l := List{}
+	var elems []Element
 
 	// 如果当前字符不是右括号，则继续解析列表
 	for p.peek() != ')' {
 		// 如果是EOF，则返回错误
 		if p.peek() == 0 {
-			return nil, ErrNotClosed{fmt.Sprintf("expected ')' at %s:%d:%d", p.file, p.line, p.col), false}
+			return nil, ErrNotClosed{Pos: p.pos(), IsString: false}
+		}
+
+		// 独立的 '.' 记号表示非正规列表（点对），其后必须恰好跟一个元素和 ')'
+		if p.peek() == '.' && isDelimiter(p.peek2()) {
+			p.read() // '.'
+			if err := p.skipWhitespace(); err != nil {
+				return nil, err
+			}
+			tail, err := p.parse()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.skipWhitespace(); err != nil {
+				return nil, err
+			}
+			if p.peek() != ')' {
+				return nil, p.errorf("expected ')' after dotted tail")
+			}
+			p.read() // ')'
+			return p.finishList(start, elems, tail, ops), nil
 		}
-		// 解析列表元素
+
+		// 解析列表元素（KeepComments 时可能是 Comment 元素）
 		e, err := p.parse()
 		if err != nil {
 			return nil, err
 		}
 
 		// 将元素添加到列表中
-		l = append(l, e)
+		elems = append(elems, e)
 
 		// 跳过空白字符
-		p.skipWhitespace()
+		if err := p.skipWhitespace(); err != nil {
+			return nil, err
+		}
 	}
 
 	// 读取右括号
 	p.read()
 
-	// 返回列表
-	if len(ops) > 0 {
-		var op string
-		for i:=len(ops)-1; i>=0; i-- {
-			op = ops[i]
-			switch op {
-			case "'":
-				l = List{Symbol{"quote"}, l}
-			case ",":
-				l = List{Symbol{"unquote"}, l}
-			default:
-				l = List{Symbol{op}, l}
-			}
+	return p.finishList(start, elems, nil, ops), nil
+}
+
+// finishList 构造列表（tail非nil时为点对列表），并按ops中的读取宏（' 和 ,）由内而外包裹
+func (p *Parser) finishList(start Pos, elems []Element, tail Element, ops []string) Element {
+	l := Element(List{Elems: elems, Tail: tail, Pos: start})
+	for i := len(ops) - 1; i >= 0; i-- {
+		switch ops[i] {
+		case "'":
+			l = List{Elems: []Element{Symbol{Name: "quote", Pos: start}, l}, Pos: start}
+		case ",":
+			l = List{Elems: []Element{Symbol{Name: "unquote", Pos: start}, l}, Pos: start}
+		default:
+			l = List{Elems: []Element{Symbol{Name: ops[i], Pos: start}, l}, Pos: start}
+		}
+	}
+	return l
+}
+
+// isDelimiter 判断ch是否为分隔符（空白、右括号或EOF）
+func isDelimiter(ch rune) bool {
+	return ch == 0 || ch == ' ' || ch == '\t' || ch == '\r' || ch == '\n' || ch == ')'
+}
+
+// isSymbolChar 判断ch是否可以作为符号/记号的一部分（用于 #t/#f 及 #\name 的延续判断）
+func isSymbolChar(ch rune) bool {
+	return !isDelimiter(ch) && ch != '(' && ch != '"'
+}
+
+// parseBool 解析 #t/#true/#f/#false 布尔字面量
+func (p *Parser) parseBool() (Element, error) {
+	start := p.pos()
+	p.read() // '#'
+
+	var b strings.Builder
+	b.WriteRune(p.read()) // 't' 或 'f'
+	for isSymbolChar(p.peek()) {
+		b.WriteRune(p.read())
+	}
+
+	switch b.String() {
+	case "t", "true":
+		return Bool{Value: true, Pos: start}, nil
+	case "f", "false":
+		return Bool{Value: false, Pos: start}, nil
+	default:
+		return nil, p.errorf("invalid boolean literal: #%s", b.String())
+	}
+}
+
+// parseChar 解析 #\a、#\space、#\newline、#\λ 等字符字面量
+func (p *Parser) parseChar() (Element, error) {
+	start := p.pos()
+	p.read() // '#'
+	p.read() // '\\'
+
+	if p.peek() == 0 {
+		return nil, p.errorf("invalid character literal")
+	}
+
+	if (p.peek() == 'u' || p.peek() == 'U') && isHexDigit(p.peek2()) {
+		p.read() // 'u'/'U'
+		hex := p.readHexDigits(6)
+		r, err := hexToRune(hex)
+		if err != nil {
+			return nil, p.errorf("invalid character literal: #\\u%s", hex)
 		}
+		return Char{Value: r, Pos: start}, nil
+	}
+
+	first := p.read()
+	if !isSymbolChar(p.peek()) {
+		// 只有一个字符，如 #\a #\( #\;
+		return Char{Value: first, Pos: start}, nil
+	}
+
+	// 多字符的具名字符，如 #\space #\newline
+	var name strings.Builder
+	name.WriteRune(first)
+	for isSymbolChar(p.peek()) {
+		name.WriteRune(p.read())
+	}
+
+	switch strings.ToLower(name.String()) {
+	case "space":
+		return Char{Value: ' ', Pos: start}, nil
+	case "newline", "linefeed":
+		return Char{Value: '\n', Pos: start}, nil
+	case "tab":
+		return Char{Value: '\t', Pos: start}, nil
+	case "return":
+		return Char{Value: '\r', Pos: start}, nil
+	case "null", "nul":
+		return Char{Value: 0, Pos: start}, nil
+	case "backspace":
+		return Char{Value: '\b', Pos: start}, nil
+	case "delete", "rubout":
+		return Char{Value: 0x7f, Pos: start}, nil
+	case "escape", "altmode":
+		return Char{Value: 0x1b, Pos: start}, nil
+	case "alarm":
+		return Char{Value: '\a', Pos: start}, nil
+	default:
+		return nil, p.errorf("unknown character name: #\\%s", name.String())
 	}
-	return l, nil
+}
+
+// parseVector 解析 #(...) 向量字面量
+func (p *Parser) parseVector() (Element, error) {
+	start := p.pos()
+	p.read() // '#'
+	p.read() // '('
+
+	if err := p.skipWhitespace(); err != nil {
+		return nil, err
+	}
+
+	var elems []Element
+	for p.peek() != ')' {
+		if p.peek() == 0 {
+			return nil, ErrNotClosed{Pos: p.pos(), IsString: false}
+		}
+		e, err := p.parse()
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, e)
+
+		if err := p.skipWhitespace(); err != nil {
+			return nil, err
+		}
+	}
+	p.read() // ')'
+
+	return Vector{Elems: elems, Pos: start}, nil
 }
 
 // parseString 解析字符串
-func (p *parser) parseString(ops ...string) (Element, error) {
+func (p *Parser) parseString(ops ...string) (Element, error) {
+	start := p.pos()
 
 	// 读取左引号
 	p.read()
@@ -194,7 +725,7 @@ func (p *parser) parseString(ops ...string) (Element, error) {
 	for {
 		// 如果是EOF，则返回错误
 		if p.peek() == 0 {
-			return nil, ErrNotClosed{fmt.Sprintf("expected '\"' at %s:%d:%d", p.file, p.line, p.col), true}
+			return nil, ErrNotClosed{Pos: p.pos(), IsString: true}
 		}
 		// 获取当前字符
 		ch := p.read()
@@ -226,11 +757,11 @@ func (p *parser) parseString(ops ...string) (Element, error) {
 	}
 
 	// 返回字符串
-	return String{b.String()}, nil
+	return String{Value: b.String(), Pos: start}, nil
 }
 
 // parseEscape 解析转义字符
-func (p *parser) parseEscape() (rune, error) {
+func (p *Parser) parseEscape() (rune, error) {
 	ch := p.read()
 	switch ch {
 	case 'n':
@@ -260,84 +791,202 @@ func (p *parser) parseEscape() (rune, error) {
 	case '"':
 		ch = '"'
 	case 'u':
-		// 读取0~4个十六进制字符
-		var hex string
-		for i := 0; i < 4; i++ {
-			ch = p.peek()
-			if ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'f' || ch >= 'A' && ch <= 'F' {
-				hex += string(p.read())
-			} else {
-				break
-			}
-		}
-
-		if len(hex) == 0 {
-			return 0, fmt.Errorf("invalid unicode escape character at %s:%d:%d", p.file, p.line, p.col)
-		}
-
-		// 将十六进制字符转换为整数
-		i, err := strconv.ParseInt(hex, 16, 64)
+		// 读取0~4个十六进制字符，与 #\u 字符字面量共用十六进制解析逻辑
+		r, err := hexToRune(p.readHexDigits(4))
 		if err != nil {
-			return 0, err
+			return 0, p.errorf("invalid unicode escape character")
 		}
-
-		// 将整数转换为字符
-		ch = rune(i)
+		ch = r
 
 	default:
-		return 0, fmt.Errorf("invalid escape character: %c at %s:%d:%d", ch, p.file, p.line, p.col)
+		return 0, p.errorf("invalid escape character: %c", ch)
 
 	}
 	return ch, nil
 }
 
-// parseNumber 解析数字
-func (p *parser) parseNumber() (Element, error) {
-	// 创建一个字符串构建器
-	var b strings.Builder
+// readHexDigits 连续读取最多maxLen个十六进制字符
+func (p *Parser) readHexDigits(maxLen int) string {
+	var hex strings.Builder
+	for i := 0; i < maxLen && isHexDigit(p.peek()); i++ {
+		hex.WriteRune(p.read())
+	}
+	return hex.String()
+}
 
-	ch := p.peek()
-	if ch == '+' || ch == '-' {
-		b.WriteRune(p.read())
+// hexToRune 将十六进制字符串转换为符文
+func hexToRune(hex string) (rune, error) {
+	if len(hex) == 0 {
+		return 0, fmt.Errorf("empty hex escape")
+	}
+	i, err := strconv.ParseInt(hex, 16, 32)
+	if err != nil {
+		return 0, err
+	}
+	return rune(i), nil
+}
+
+// parseNumber 解析数字：十进制整数/浮点数（含科学计数法）、0x/0o/0b整数、
+// 有理数字面量（如 3/4）、+inf.0/-inf.0/+nan.0，以及溢出int64时提升为BigInt
+func (p *Parser) parseNumber() (Element, error) {
+	start := p.pos()
+
+	var sign string
+	neg := false
+	if ch := p.peek(); ch == '+' || ch == '-' {
+		sign = string(p.read())
+		neg = sign == "-"
+	}
+
+	if sign != "" {
+		if p.tryConsumeLiteral("inf.0") {
+			if neg {
+				return Float{Value: math.Inf(-1), Pos: start}, nil
+			}
+			return Float{Value: math.Inf(1), Pos: start}, nil
+		}
+		if p.tryConsumeLiteral("nan.0") {
+			return Float{Value: math.NaN(), Pos: start}, nil
+		}
+	}
+
+	if p.peek() == '0' {
+		switch p.peek2() {
+		case 'x', 'X':
+			return p.parseRadixInteger(start, neg, 16, isHexDigit)
+		case 'o', 'O':
+			return p.parseRadixInteger(start, neg, 8, isOctDigit)
+		case 'b', 'B':
+			return p.parseRadixInteger(start, neg, 2, isBinDigit)
+		}
 	}
 
-	// 统计小数点数量
-	var hasDot bool
+	// 创建一个字符串构建器
+	var b strings.Builder
+	b.WriteString(sign)
+
+	var hasDot, hasExp, hasDigit bool
 
 	// 读取数字
 	for {
 		// 获取当前字符
 		ch := p.peek()
 
-		if ch == '.' {
-			if hasDot {
-				return nil, fmt.Errorf("invalid number at %s:%d:%d", p.file, p.line, p.col)
-			}
+		switch {
+		case ch >= '0' && ch <= '9':
+			hasDigit = true
+			b.WriteRune(p.read())
+			continue
+		case ch == '.' && !hasDot && !hasExp:
 			hasDot = true
+			b.WriteRune(p.read())
+			continue
+		case (ch == 'e' || ch == 'E') && hasDigit && !hasExp:
+			next := p.peek2()
+			if next == '+' || next == '-' || (next >= '0' && next <= '9') {
+				hasExp = true
+				b.WriteRune(p.read())
+				if p.peek() == '+' || p.peek() == '-' {
+					b.WriteRune(p.read())
+				}
+				continue
+			}
+		case ch == '.':
+			return nil, p.errorf("invalid number")
 		}
+		break
+	}
 
-		// 如果是数字或者小数点，则读取字符
-		if (ch >= '0' && ch <= '9') || ch == '.' {
-			b.WriteRune(p.read())
-		} else {
-			break
+	// 有理数字面量：整数部分之后紧跟 '/' 和分母
+	if !hasDot && !hasExp && p.peek() == '/' && isDigit(p.peek2()) {
+		p.read() // '/'
+		var denom strings.Builder
+		for isDigit(p.peek()) {
+			denom.WriteRune(p.read())
 		}
+		r := new(big.Rat)
+		if _, ok := r.SetString(b.String() + "/" + denom.String()); !ok {
+			return nil, p.errorf("invalid rational number")
+		}
+		return Rational{Value: r, Pos: start}, nil
 	}
 
 	// 将字符串转换为数字
-	if hasDot {
+	if hasDot || hasExp {
 		f, err := strconv.ParseFloat(b.String(), 64)
 		if err != nil {
-			return nil, err
+			return nil, p.errorf("invalid number: %v", err)
 		}
-		return Float(f), nil
-	} else {
-		i, err := strconv.ParseInt(b.String(), 10, 64)
-		if err != nil {
-			return nil, err
+		return Float{Value: f, Pos: start}, nil
+	}
+
+	i, err := strconv.ParseInt(b.String(), 10, 64)
+	if err != nil {
+		// int64溢出，提升为BigInt
+		bi, ok := new(big.Int).SetString(b.String(), 10)
+		if !ok {
+			return nil, p.errorf("invalid number")
+		}
+		return BigInt{Value: bi, Pos: start}, nil
+	}
+	return Integer{Value: i, Pos: start}, nil
+}
+
+// parseRadixInteger 解析 0x/0o/0b 前缀的整数，溢出int64时提升为BigInt
+func (p *Parser) parseRadixInteger(start Pos, neg bool, radix int, valid func(rune) bool) (Element, error) {
+	p.read() // '0'
+	p.read() // x/o/b
+
+	var digits strings.Builder
+	for valid(p.peek()) {
+		digits.WriteRune(p.read())
+	}
+	if digits.Len() == 0 {
+		return nil, p.errorf("invalid number: missing digits after radix prefix")
+	}
+
+	bi, ok := new(big.Int).SetString(digits.String(), radix)
+	if !ok {
+		return nil, p.errorf("invalid number")
+	}
+	if neg {
+		bi.Neg(bi)
+	}
+
+	if bi.IsInt64() {
+		return Integer{Value: bi.Int64(), Pos: start, Radix: radix}, nil
+	}
+	return BigInt{Value: bi, Pos: start}, nil
+}
+
+// tryConsumeLiteral 检查接下来的字符是否恰好是 lit，若是则消费并返回 true，否则不消费任何字符
+func (p *Parser) tryConsumeLiteral(lit string) bool {
+	runes := []rune(lit)
+	for i, r := range runes {
+		if p.peekAt(i) != r {
+			return false
 		}
-		return Integer(i), nil
 	}
+	for range runes {
+		p.read()
+	}
+	return true
+}
+
+func isDigit(ch rune) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func isHexDigit(ch rune) bool {
+	return isDigit(ch) || (ch >= 'a' && ch <= 'f') || (ch >= 'A' && ch <= 'F')
+}
+
+func isOctDigit(ch rune) bool {
+	return ch >= '0' && ch <= '7'
+}
+
+func isBinDigit(ch rune) bool {
+	return ch == '0' || ch == '1'
 }
 
 func splitOp(opString string) []string {
@@ -361,7 +1010,9 @@ func splitOp(opString string) []string {
 }
 
 // parseSymbol 解析符号
-func (p *parser) parseSymbol() (Element, error) {
+func (p *Parser) parseSymbol() (Element, error) {
+	start := p.pos()
+
 	// 创建一个字符串构建器
 	var b strings.Builder
 
@@ -389,44 +1040,185 @@ func (p *parser) parseSymbol() (Element, error) {
 	}
 
 	// 返回符号
-	return Symbol{b.String()}, nil
+	return Symbol{Name: b.String(), Pos: start}, nil
 }
 
-// skipWhitespace 跳过空白字符
-func (p *parser) skipWhitespace() {
+// skipWhitespace 跳过空白字符。当 KeepComments 为 false 时，也会把 `;` 行注释、
+// `#| |#` 块注释、`#;` 数据注释当作空白字符一并跳过（`#;` 会递归调用 parse
+// 解析并丢弃紧随其后的一个元素）；KeepComments 为 true 时则都原样留给
+// parse 的分发逻辑处理，以便保留为 Comment 元素
+func (p *Parser) skipWhitespace() error {
 	for {
 		// 获取当前字符
 		ch := p.peek()
 
-		// 如果是空白字符，则继续读取
-		if ch == ' ' || ch == '\t' || ch == '\r' || ch == '\n' {
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\r' || ch == '\n':
 			p.read()
-		} else {
+		case !p.KeepComments && ch == '#' && p.peek2() == ';':
+			p.read()
+			p.read()
+			if _, err := p.parse(); err != nil {
+				return err
+			}
+		case !p.KeepComments && ch == ';':
+			p.skipLineComment()
+		case !p.KeepComments && ch == '#' && p.peek2() == '|':
+			if err := p.skipBlockComment(); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+}
+
+// skipLineComment 跳过从 `;` 到行末（不含换行符）的内容
+func (p *Parser) skipLineComment() {
+	p.read() // ';'
+	for {
+		ch := p.peek()
+		if ch == 0 || ch == '\n' {
+			return
+		}
+		p.read()
+	}
+}
+
+// skipBlockComment 跳过一个（可嵌套的）`#| ... |#` 块注释
+func (p *Parser) skipBlockComment() error {
+	start := p.pos()
+	p.read() // '#'
+	p.read() // '|'
+	depth := 1
+	for depth > 0 {
+		ch := p.peek()
+		switch {
+		case ch == 0:
+			return &ParseError{Pos: start, Err: fmt.Errorf("unterminated block comment")}
+		case ch == '#' && p.peek2() == '|':
+			p.read()
+			p.read()
+			depth++
+		case ch == '|' && p.peek2() == '#':
+			p.read()
+			p.read()
+			depth--
+		default:
+			p.read()
+		}
+	}
+	return nil
+}
+
+// parseLineComment 解析一个 `;` 行注释，保留其原始文本
+func (p *Parser) parseLineComment() (Element, error) {
+	start := p.pos()
+	var b strings.Builder
+	b.WriteRune(p.read()) // ';'
+	for {
+		ch := p.peek()
+		if ch == 0 || ch == '\n' {
 			break
 		}
+		b.WriteRune(p.read())
 	}
+	return Comment{Text: b.String(), Pos: start}, nil
 }
 
-// peek 返回当前字符
-func (p *parser) peek() rune {
-	if p.pos >= len(p.input) {
+// parseBlockComment 解析一个（可嵌套的）`#| ... |#` 块注释，保留其原始文本
+func (p *Parser) parseBlockComment() (Element, error) {
+	start := p.pos()
+	var b strings.Builder
+	b.WriteRune(p.read()) // '#'
+	b.WriteRune(p.read()) // '|'
+	depth := 1
+	for depth > 0 {
+		ch := p.peek()
+		switch {
+		case ch == 0:
+			return nil, &ParseError{Pos: start, Err: fmt.Errorf("unterminated block comment")}
+		case ch == '#' && p.peek2() == '|':
+			b.WriteRune(p.read())
+			b.WriteRune(p.read())
+			depth++
+		case ch == '|' && p.peek2() == '#':
+			b.WriteRune(p.read())
+			b.WriteRune(p.read())
+			depth--
+		default:
+			b.WriteRune(p.read())
+		}
+	}
+	return Comment{Text: b.String(), Pos: start}, nil
+}
+
+// parseDatumComment 解析一个 `#;` 数据注释：丢弃紧随其后的一个元素，
+// 仅在 KeepComments 为 true 时会被调用，以便把它保留在结果中
+func (p *Parser) parseDatumComment() (Element, error) {
+	start := p.pos()
+	p.read() // '#'
+	p.read() // ';'
+	discarded, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	return Comment{Text: "#;" + discarded.String(), Pos: start}, nil
+}
+
+// fill 确保预读缓冲区中至少有 n+1 个符文（到达EOF时可能更少）
+func (p *Parser) fill(n int) {
+	for len(p.buf) <= n {
+		ch, _, err := p.r.ReadRune()
+		if err != nil {
+			break
+		}
+		p.buf = append(p.buf, ch)
+	}
+}
+
+// peekAt 返回从当前位置起第 n 个符文（n=0即下一个待读符文），到达EOF时返回0
+func (p *Parser) peekAt(n int) rune {
+	p.fill(n)
+	if n >= len(p.buf) {
 		return 0
 	}
-	return p.input[p.pos]
+	return p.buf[n]
+}
+
+// peek 返回当前字符
+func (p *Parser) peek() rune {
+	return p.peekAt(0)
+}
+
+// peek2 返回再往后一个字符，用于识别 #| #; 0x 等双字符记号
+func (p *Parser) peek2() rune {
+	return p.peekAt(1)
 }
 
 // read 读取当前字符，并将位置后移，同时统计行号和列号
-func (p *parser) read() rune {
-	// fmt.Printf("read %d: %c", p.pos, p.peek())
+func (p *Parser) read() rune {
 	ch := p.peek()
+	if ch == 0 {
+		return 0
+	}
+	p.buf = p.buf[1:]
+	p.lastLine, p.lastCol = p.line, p.col
+	p.hasLast = true
 	if ch == '\n' {
 		p.line++
-		p.col = 0
+		p.col = 1
 	} else {
 		p.col++
 	}
-	p.pos++
 	return ch
 }
 
-
+// unread 将上一次读取的字符放回缓冲区，仅支持回退最近读取的一个字符
+func (p *Parser) unread(ch rune) {
+	p.buf = append([]rune{ch}, p.buf...)
+	if p.hasLast {
+		p.line, p.col = p.lastLine, p.lastCol
+		p.hasLast = false
+	}
+}